@@ -0,0 +1,170 @@
+// Package failure implements a phi accrual failure detector, as
+// described in Hayashibara et al., "The φ Accrual Failure Detector"
+// (2004), for use in distributed systems that monitor peer liveness via
+// heartbeats.
+package failure
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Armadilloa16/stat/dist"
+)
+
+// DefaultWindowSize is the number of most recent inter-arrival
+// intervals a Detector retains when none is specified to NewDetector.
+const DefaultWindowSize = 1000
+
+// Backend fits a distribution to a window of observed inter-arrival
+// intervals and reports the log-probability of an interval as long as
+// delta under that fit. LogSurvival is used, rather than the survival
+// probability itself, so that Phi stays finite and keeps growing
+// smoothly even once delta is many standard deviations out into the
+// tail, where the survival probability itself would underflow to zero.
+type Backend interface {
+	// LogSurvival returns the estimated log-probability that an
+	// inter-arrival interval at least as long as delta is drawn, given
+	// the sample of historical intervals.
+	LogSurvival(intervals []float64, delta float64) float64
+}
+
+// NormalBackend fits a normal distribution to the window of intervals
+// and reports survival from its CDF. This is the classical backend
+// described by Hayashibara et al. Its LogSurvival is the log of the
+// normal survival probability itself, so it reaches -Inf, and Phi +Inf,
+// once delta is about 38 standard deviations past the mean and that
+// probability underflows.
+type NormalBackend struct{}
+
+// LogSurvival implements Backend.
+func (NormalBackend) LogSurvival(intervals []float64, delta float64) float64 {
+	if len(intervals) == 0 {
+		return 0
+	}
+	var mean float64
+	for _, v := range intervals {
+		mean += v
+	}
+	mean /= float64(len(intervals))
+
+	if len(intervals) < 2 {
+		if delta >= mean {
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	var ss float64
+	for _, v := range intervals {
+		d := v - mean
+		ss += d * d
+	}
+	stddev := math.Sqrt(ss / float64(len(intervals)-1))
+	if stddev == 0 {
+		if delta >= mean {
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	return math.Log(math.Erfc((delta-mean)/(stddev*math.Sqrt2)) / 2)
+}
+
+// WeibullBackend fits a Weibull distribution to the window of intervals
+// via dist.Weibull.Fit and reports log-survival from its analytic log
+// survival function, -(Δt/λ)^K, which stays numerically well behaved far
+// into the tail. A Weibull fit also captures the heavy right tail
+// typical of network heartbeat jitter better than a normal
+// approximation.
+type WeibullBackend struct{}
+
+// LogSurvival implements Backend.
+func (WeibullBackend) LogSurvival(intervals []float64, delta float64) float64 {
+	if len(intervals) == 0 {
+		return 0
+	}
+	if len(intervals) < 2 {
+		if delta >= intervals[0] {
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	fit, _, _ := dist.Weibull{}.Fit(intervals, nil)
+	return fit.LogSurvival(delta)
+}
+
+// Detector is a phi accrual failure detector. It tracks the
+// inter-arrival times of heartbeats from a single peer in a sliding
+// window and, given the elapsed time since the last heartbeat, reports
+// a suspicion level that grows smoothly rather than tripping a fixed
+// timeout.
+//
+// A Detector is safe for concurrent use.
+type Detector struct {
+	mu      sync.Mutex
+	backend Backend
+
+	intervals []float64
+	head      int
+	filled    int
+
+	last    time.Time
+	hasLast bool
+}
+
+// NewDetector returns a Detector that fits intervals using backend and
+// retains the windowSize most recent inter-arrival intervals. If
+// windowSize is zero or negative, DefaultWindowSize is used.
+func NewDetector(backend Backend, windowSize int) *Detector {
+	if windowSize <= 0 {
+		windowSize = DefaultWindowSize
+	}
+	return &Detector{
+		backend:   backend,
+		intervals: make([]float64, windowSize),
+	}
+}
+
+// Heartbeat records a heartbeat received at time t, adding the interval
+// since the previous heartbeat to the sliding window. A heartbeat whose
+// interval since the previous one is zero or negative, from a duplicate
+// timestamp or clock skew, is not added to the window, since fitting a
+// Weibull or normal distribution to such a value would poison the fit
+// with -Inf or NaN.
+func (d *Detector) Heartbeat(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.hasLast {
+		if interval := t.Sub(d.last).Seconds(); interval > 0 {
+			d.intervals[d.head] = interval
+			d.head = (d.head + 1) % len(d.intervals)
+			if d.filled < len(d.intervals) {
+				d.filled++
+			}
+		}
+	}
+	d.last = t
+	d.hasLast = true
+}
+
+// Phi returns the suspicion level at time t, computed as
+// -log10(1 - CDF(Δt)) where Δt is the elapsed time since the last
+// recorded heartbeat and CDF is refit from the current window of
+// intervals. Phi is 0 before any heartbeat has been recorded, and grows
+// without bound as Δt grows past the observed interval distribution.
+func (d *Detector) Phi(t time.Time) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.hasLast || d.filled == 0 {
+		return 0
+	}
+	delta := t.Sub(d.last).Seconds()
+	logSurvival := d.backend.LogSurvival(d.intervals[:d.filled], delta)
+	return -logSurvival / math.Ln10
+}
+
+// Suspicion is an alias for Phi, named for callers that treat the
+// detector as reporting a suspicion level rather than the phi value
+// specifically.
+func (d *Detector) Suspicion(t time.Time) float64 {
+	return d.Phi(t)
+}
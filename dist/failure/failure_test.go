@@ -0,0 +1,90 @@
+package failure
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDetectorPhiGrowsWithDelay(t *testing.T) {
+	d := NewDetector(WeibullBackend{}, 0)
+	base := time.Unix(0, 0)
+	// Jittered, rather than perfectly periodic, intervals: a Detector
+	// fed identical intervals fits a near-degenerate Weibull whose
+	// LogSurvival legitimately reaches -Inf a short way past the mean,
+	// which isn't the "grows smoothly" behavior under test here.
+	jitterMillis := []int{0, 50, -30, 20, -10, 40, -20, 10, 0, 30, -40, 15, -15, 25, -25, 35, -35, 5, -5, 45}
+	elapsed := 0
+	for i, j := range jitterMillis {
+		if i > 0 {
+			elapsed += 1000 + j
+		}
+		d.Heartbeat(base.Add(time.Duration(elapsed) * time.Millisecond))
+	}
+	last := base.Add(time.Duration(elapsed) * time.Millisecond)
+
+	onTime := d.Phi(last.Add(time.Second))
+	late := d.Phi(last.Add(10 * time.Second))
+	if late <= onTime {
+		t.Errorf("Phi(late) = %v, Phi(on time) = %v, want late > on time", late, onTime)
+	}
+	if math.IsNaN(onTime) || math.IsInf(onTime, 0) {
+		t.Errorf("Phi(on time) = %v, want a finite value", onTime)
+	}
+	if math.IsNaN(late) || math.IsInf(late, 0) {
+		t.Errorf("Phi(late) = %v, want a finite value", late)
+	}
+}
+
+func TestDetectorPhiZeroBeforeFirstHeartbeat(t *testing.T) {
+	d := NewDetector(NormalBackend{}, 0)
+	if phi := d.Phi(time.Unix(0, 0)); phi != 0 {
+		t.Errorf("Phi() before any heartbeat = %v, want 0", phi)
+	}
+}
+
+// TestDetectorSkipsNonPositiveIntervals checks that a duplicate or
+// out-of-order heartbeat timestamp does not poison the window with a
+// zero or negative interval, which would otherwise send LogSurvival's
+// underlying fit to NaN or -Inf for every later Phi call.
+func TestDetectorSkipsNonPositiveIntervals(t *testing.T) {
+	d := NewDetector(WeibullBackend{}, 10)
+	base := time.Unix(0, 0)
+	d.Heartbeat(base)
+	d.Heartbeat(base)                   // duplicate timestamp: zero interval
+	d.Heartbeat(base.Add(-time.Second)) // out of order: negative interval
+	d.Heartbeat(base.Add(1 * time.Second))
+	d.Heartbeat(base.Add(2 * time.Second))
+	d.Heartbeat(base.Add(3 * time.Second))
+
+	phi := d.Phi(base.Add(4 * time.Second))
+	if math.IsNaN(phi) || math.IsInf(phi, 0) {
+		t.Errorf("Phi() = %v after non-positive intervals, want a finite value", phi)
+	}
+}
+
+func TestNormalBackendLogSurvival(t *testing.T) {
+	intervals := []float64{1, 1, 1, 1, 1}
+	if ls := (NormalBackend{}).LogSurvival(intervals, 0); ls != 0 {
+		t.Errorf("LogSurvival(0) = %v, want 0 for delta below the mean", ls)
+	}
+	far := NormalBackend{}.LogSurvival(intervals, 100)
+	if !math.IsInf(far, -1) {
+		t.Errorf("LogSurvival(100) = %v, want -Inf for a zero-variance sample and delta past the mean", far)
+	}
+}
+
+func TestWeibullBackendLogSurvival(t *testing.T) {
+	intervals := make([]float64, 30)
+	for i := range intervals {
+		intervals[i] = 1
+	}
+	near := WeibullBackend{}.LogSurvival(intervals, 0.5)
+	far := WeibullBackend{}.LogSurvival(intervals, 2)
+	if math.IsNaN(near) || math.IsNaN(far) {
+		t.Errorf("LogSurvival returned NaN: near=%v far=%v", near, far)
+	}
+	if far >= near {
+		t.Errorf("LogSurvival(2) = %v, LogSurvival(0.5) = %v, want the larger delta to have lower log-survival", far, near)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2014 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestFitConstantSamples checks that Fit returns a finite distribution
+// when every sample is identical, a degenerate (zero log-scale variance)
+// input that previously sent K, and then λ, to +Inf, NaN, or 0 via
+// overflow/underflow in the Newton search.
+func TestFitConstantSamples(t *testing.T) {
+	for _, c := range []float64{1, 5, 0.2, 100} {
+		samples := make([]float64, 29)
+		for i := range samples {
+			samples[i] = c
+		}
+		fit, _, _ := Weibull{}.Fit(samples, nil)
+		if math.IsNaN(fit.K) || math.IsInf(fit.K, 0) || fit.K <= 0 {
+			t.Errorf("Fit(constant %v): K = %v, want a finite positive value", c, fit.K)
+		}
+		if math.IsNaN(fit.Lambda) || math.IsInf(fit.Lambda, 0) || fit.Lambda <= 0 {
+			t.Errorf("Fit(constant %v): Lambda = %v, want a finite positive value", c, fit.Lambda)
+		}
+		if ls := fit.LogSurvival(c * 1.1); math.IsNaN(ls) {
+			t.Errorf("Fit(constant %v): LogSurvival(1.1c) = NaN, want a finite or -Inf value", c)
+		}
+	}
+}
+
+// TestFitCensored checks that FitCensored recovers K and λ close to the
+// values a sample was drawn from when the top 30% of the sample is
+// right-censored at its 70th-percentile value. This is the scenario
+// FitCensored exists for, and previously returned K and λ off by two
+// orders of magnitude because the score equation's Σlog(x) term summed
+// over censored observations too, instead of over uncensored ones only.
+func TestFitCensored(t *testing.T) {
+	const wantK, wantLambda = 2.3, 5.0
+	src := rand.New(rand.NewSource(1))
+	true_ := Weibull{K: wantK, Lambda: wantLambda, Source: src}
+
+	n := 5000
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = true_.Rand()
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	cutoff := sorted[int(0.7*float64(n))]
+
+	censored := make([]bool, n)
+	for i, x := range samples {
+		if x > cutoff {
+			samples[i] = cutoff
+			censored[i] = true
+		}
+	}
+
+	fit, stderrK, stderrLambda := Weibull{}.FitCensored(samples, nil, censored)
+	if math.Abs(fit.K-wantK) > 0.2 {
+		t.Errorf("FitCensored: K = %v, want close to %v", fit.K, wantK)
+	}
+	if math.Abs(fit.Lambda-wantLambda) > 0.2 {
+		t.Errorf("FitCensored: Lambda = %v, want close to %v", fit.Lambda, wantLambda)
+	}
+	if math.IsNaN(stderrK) || math.IsNaN(stderrLambda) {
+		t.Errorf("FitCensored: stderrK = %v, stderrLambda = %v, want finite values", stderrK, stderrLambda)
+	}
+}
+
+// TestFitCensoredAllCensoredPanics checks that FitCensored fails loudly,
+// rather than silently returning Lambda = +Inf, when every observation
+// is censored and there is no uncensored data to anchor the fit.
+func TestFitCensoredAllCensoredPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("FitCensored with all samples censored did not panic")
+		}
+	}()
+	samples := []float64{1, 2, 3}
+	censored := []bool{true, true, true}
+	Weibull{}.FitCensored(samples, nil, censored)
+}
@@ -0,0 +1,385 @@
+// Package hypothesis provides paired and unpaired comparison tests
+// suitable for benchmark and A/B analysis, along with the sample
+// cleaning and summary helpers commonly used alongside them.
+package hypothesis
+
+import (
+	"math"
+	"sort"
+)
+
+// Test compares two samples and returns a test statistic and the
+// corresponding two-sided p-value for the null hypothesis that they are
+// drawn from the same distribution (or, for paired tests, that their
+// paired differences are centred on zero). It allows the choice of
+// comparison test to be made by the caller of generic code.
+type Test interface {
+	Test(xs, ys []float64) (statistic, p float64)
+}
+
+// MannWhitney is a Test that performs the Mann-Whitney U test.
+type MannWhitney struct{}
+
+// Test implements Test.
+func (MannWhitney) Test(xs, ys []float64) (statistic, p float64) {
+	return MannWhitneyU(xs, ys)
+}
+
+// Welch is a Test that performs Welch's t-test.
+type Welch struct{}
+
+// Test implements Test.
+func (Welch) Test(xs, ys []float64) (statistic, p float64) {
+	t, _, p := WelchT(xs, ys)
+	return t, p
+}
+
+// MannWhitneyU performs the two-sided Mann-Whitney U test comparing xs
+// and ys, returning the U statistic (the smaller of the two rank-sum
+// derived statistics) and its p-value.
+//
+// The null distribution of U is evaluated exactly, by the classical
+// recurrence for the number of rank arrangements giving each value of U,
+// when len(xs)+len(ys) is at most 20 and the combined sample has no
+// ties; for larger samples, or any sample with ties, a normal
+// approximation with a tie correction is used instead.
+func MannWhitneyU(xs, ys []float64) (U, p float64) {
+	n1, n2 := len(xs), len(ys)
+	if n1 == 0 || n2 == 0 {
+		panic("hypothesis: empty sample")
+	}
+
+	combined := make([]float64, 0, n1+n2)
+	combined = append(combined, xs...)
+	combined = append(combined, ys...)
+	ranks, tieCorrection := midrank(combined)
+
+	var rankSum1 float64
+	for i := 0; i < n1; i++ {
+		rankSum1 += ranks[i]
+	}
+	U1 := rankSum1 - float64(n1*(n1+1))/2
+	U2 := float64(n1*n2) - U1
+	U = math.Min(U1, U2)
+
+	if n1+n2 <= 20 && tieCorrection == 0 {
+		p = mannWhitneyExactP(n1, n2, U)
+		return U, p
+	}
+
+	meanU := float64(n1*n2) / 2
+	n := float64(n1 + n2)
+	varU := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if varU <= 0 {
+		return U, 1
+	}
+	z := (U - meanU) / math.Sqrt(varU)
+	p = 2 * (1 - normalCDF(math.Abs(z)))
+	if p > 1 {
+		p = 1
+	}
+	return U, p
+}
+
+// mannWhitneyExactP returns the two-sided p-value for a Mann-Whitney U
+// statistic computed under no ties, via the exact recurrence for the
+// number of ways n1 "x" labels and n2 "y" labels can be arranged to give
+// each value of U.
+func mannWhitneyExactP(n1, n2 int, U float64) float64 {
+	counts := mannWhitneyCounts(n1, n2)
+	var total, cdfLow, cdfHigh float64
+	u := int(math.Round(U))
+	for k, c := range counts {
+		total += c
+		if k <= u {
+			cdfLow += c
+		}
+		if k >= u {
+			cdfHigh += c
+		}
+	}
+	p := 2 * math.Min(cdfLow, cdfHigh) / total
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// mannWhitneyCounts returns, for i in range, the number of ways to
+// arrange n1 "x" labels and n2 "y" labels among n1+n2 ranks such that
+// the Mann-Whitney U statistic for the "x" labels equals i, via the
+// recurrence f(n1,n2,u) = f(n1-1,n2,u-n2) + f(n1,n2-1,u).
+func mannWhitneyCounts(n1, n2 int) []float64 {
+	dp := make([][][]float64, n1+1)
+	for i := range dp {
+		dp[i] = make([][]float64, n2+1)
+	}
+	for i := 0; i <= n1; i++ {
+		dp[i][0] = []float64{1}
+	}
+	for j := 0; j <= n2; j++ {
+		dp[0][j] = []float64{1}
+	}
+	for i := 1; i <= n1; i++ {
+		for j := 1; j <= n2; j++ {
+			a := dp[i-1][j]
+			b := dp[i][j-1]
+			row := make([]float64, i*j+1)
+			for u := range row {
+				var v float64
+				if u-j >= 0 && u-j < len(a) {
+					v += a[u-j]
+				}
+				if u < len(b) {
+					v += b[u]
+				}
+				row[u] = v
+			}
+			dp[i][j] = row
+		}
+	}
+	return dp[n1][n2]
+}
+
+// WelchT performs Welch's t-test comparing the means of xs and ys
+// without assuming equal variances, returning the t statistic, the
+// Welch-Satterthwaite degrees of freedom, and the two-sided p-value.
+func WelchT(xs, ys []float64) (t, df, p float64) {
+	mean1, var1 := meanVariance(xs)
+	mean2, var2 := meanVariance(ys)
+	n1, n2 := float64(len(xs)), float64(len(ys))
+
+	se1 := var1 / n1
+	se2 := var2 / n2
+	t = (mean1 - mean2) / math.Sqrt(se1+se2)
+	df = (se1 + se2) * (se1 + se2) / (se1*se1/(n1-1) + se2*se2/(n2-1))
+	p = 2 * (1 - studentTCDF(math.Abs(t), df))
+	return t, df, p
+}
+
+// Wilcoxon performs the Wilcoxon signed-rank test on paired
+// observations, each element of pairs holding a before/after (or
+// treatment/control) pair. Pairs with a zero difference are dropped.
+// Wilcoxon returns the signed-rank sum W (the sum of ranks of the
+// positive differences) and the two-sided p-value from the normal
+// approximation with a tie correction.
+func Wilcoxon(pairs [][2]float64) (W, p float64) {
+	diffs := make([]float64, 0, len(pairs))
+	for _, pr := range pairs {
+		if d := pr[0] - pr[1]; d != 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	n := len(diffs)
+	if n == 0 {
+		return 0, 1
+	}
+
+	absDiffs := make([]float64, n)
+	for i, d := range diffs {
+		absDiffs[i] = math.Abs(d)
+	}
+	ranks, tieCorrection := midrank(absDiffs)
+
+	for i, d := range diffs {
+		if d > 0 {
+			W += ranks[i]
+		}
+	}
+
+	meanW := float64(n*(n+1)) / 4
+	varW := float64(n*(n+1)*(2*n+1))/24 - tieCorrection/48
+	if varW <= 0 {
+		return W, 1
+	}
+	z := (W - meanW) / math.Sqrt(varW)
+	p = 2 * (1 - normalCDF(math.Abs(z)))
+	if p > 1 {
+		p = 1
+	}
+	return W, p
+}
+
+// IQROutliers splits xs into kept and dropped observations using the
+// 1.5·IQR rule: an observation is dropped if it lies more than 1.5 times
+// the interquartile range below the first quartile or above the third.
+func IQROutliers(xs []float64) (kept, dropped []float64) {
+	if len(xs) == 0 {
+		panic("hypothesis: empty sample")
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	q1 := percentile(sorted, 0.25)
+	q3 := percentile(sorted, 0.75)
+	iqr := q3 - q1
+	lo := q1 - 1.5*iqr
+	hi := q3 + 1.5*iqr
+	for _, x := range xs {
+		if x < lo || x > hi {
+			dropped = append(dropped, x)
+		} else {
+			kept = append(kept, x)
+		}
+	}
+	return kept, dropped
+}
+
+// GeoMean returns the geometric mean of xs, which must contain only
+// positive values.
+func GeoMean(xs []float64) float64 {
+	if len(xs) == 0 {
+		panic("hypothesis: empty sample")
+	}
+	var sumLog float64
+	for _, x := range xs {
+		sumLog += math.Log(x)
+	}
+	return math.Exp(sumLog / float64(len(xs)))
+}
+
+// percentile returns the pth percentile (0 <= p <= 1) of sorted, which
+// must already be sorted in ascending order, via linear interpolation
+// between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := p * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo] + frac*(sorted[hi]-sorted[lo])
+}
+
+// meanVariance returns the unweighted mean and sample variance of xs.
+func meanVariance(xs []float64) (mean, variance float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		d := x - mean
+		variance += d * d
+	}
+	variance /= float64(len(xs) - 1)
+	return mean, variance
+}
+
+// midrank returns, for each element of x, the average of the 1-based
+// ranks it would occupy among equal elements, along with the tie
+// correction term Σ(tᵢ³-tᵢ) used by the normal approximations above,
+// where tᵢ is the size of the ith group of tied values.
+func midrank(x []float64) (ranks []float64, tieCorrection float64) {
+	n := len(x)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return x[idx[i]] < x[idx[j]] })
+
+	ranks = make([]float64, n)
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && x[idx[j+1]] == x[idx[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		t := float64(j - i + 1)
+		tieCorrection += t*t*t - t
+		for k := i; k <= j; k++ {
+			ranks[idx[k]] = rank
+		}
+		i = j + 1
+	}
+	return ranks, tieCorrection
+}
+
+// normalCDF returns the value of the standard normal cumulative
+// distribution function at x.
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// studentTCDF returns the value of Student's t cumulative distribution
+// function with df degrees of freedom at t.
+func studentTCDF(t, df float64) float64 {
+	x := df / (df + t*t)
+	ib := regIncBeta(df/2, 0.5, x)
+	if t >= 0 {
+		return 1 - 0.5*ib
+	}
+	return 0.5 * ib
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b),
+// via the continued fraction expansion of Numerical Recipes.
+func regIncBeta(a, b, x float64) float64 {
+	if x < 0 || x > 1 {
+		panic("hypothesis: x out of range")
+	}
+	if x == 0 || x == 1 {
+		return x
+	}
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	bt := math.Exp(lgAB - lgA - lgB + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return bt * betacf(a, b, x) / a
+	}
+	return 1 - bt*betacf(b, a, 1-x)/b
+}
+
+// betacf evaluates the continued fraction for the incomplete beta
+// function by modified Lentz's method.
+func betacf(a, b, x float64) float64 {
+	const (
+		maxIter = 200
+		eps     = 3e-14
+		fpMin   = 1e-300
+	)
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < fpMin {
+		d = fpMin
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		m2 := float64(2 * m)
+		aa := float64(m) * (b - float64(m)) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpMin {
+			d = fpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpMin {
+			c = fpMin
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + float64(m)) * (qab + float64(m)) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpMin {
+			d = fpMin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpMin {
+			c = fpMin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
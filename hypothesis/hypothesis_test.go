@@ -0,0 +1,120 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMannWhitneyUSeparated checks the exact null distribution path
+// against a hand-computable case: with xs entirely below ys and no
+// ties, there is exactly one arrangement (out of C(6,3)=20) giving
+// U=0, so the two-sided p-value is 2/20 = 0.1.
+func TestMannWhitneyUSeparated(t *testing.T) {
+	xs := []float64{1, 2, 3}
+	ys := []float64{4, 5, 6}
+	U, p := MannWhitneyU(xs, ys)
+	if U != 0 {
+		t.Errorf("MannWhitneyU() U = %v, want 0", U)
+	}
+	if math.Abs(p-0.1) > 1e-9 {
+		t.Errorf("MannWhitneyU() p = %v, want 0.1", p)
+	}
+}
+
+func TestMannWhitneyUIdenticalDistributions(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5}
+	ys := []float64{1, 2, 3, 4, 5}
+	_, p := MannWhitneyU(xs, ys)
+	if p != 1 {
+		t.Errorf("MannWhitneyU() p = %v, want 1 for identical samples", p)
+	}
+}
+
+func TestMannWhitneyUEmptySamplePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MannWhitneyU with an empty sample did not panic")
+		}
+	}()
+	MannWhitneyU(nil, []float64{1, 2, 3})
+}
+
+// TestWelchT checks the t statistic and degrees of freedom against
+// hand-computed values for two samples with different variances.
+func TestWelchT(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5}
+	ys := []float64{2, 4, 6, 8, 10}
+	stat, df, p := WelchT(xs, ys)
+
+	wantT := -1.8973665961010275
+	wantDF := 5.882352941176471
+	wantP := 0.10753119493062657
+	if math.Abs(stat-wantT) > 1e-9 {
+		t.Errorf("WelchT() t = %v, want %v", stat, wantT)
+	}
+	if math.Abs(df-wantDF) > 1e-9 {
+		t.Errorf("WelchT() df = %v, want %v", df, wantDF)
+	}
+	if math.Abs(p-wantP) > 1e-9 {
+		t.Errorf("WelchT() p = %v, want %v", p, wantP)
+	}
+}
+
+// TestWilcoxon checks the signed-rank sum and p-value against a
+// hand-computable case where every paired difference is positive and
+// distinct, so ranks are 1..n with no ties.
+func TestWilcoxon(t *testing.T) {
+	pairs := [][2]float64{{2, 1}, {4, 2}, {6, 3}, {8, 4}}
+	W, p := Wilcoxon(pairs)
+	if W != 10 {
+		t.Errorf("Wilcoxon() W = %v, want 10", W)
+	}
+	wantP := 0.067889154861829
+	if math.Abs(p-wantP) > 1e-9 {
+		t.Errorf("Wilcoxon() p = %v, want %v", p, wantP)
+	}
+}
+
+func TestWilcoxonAllZeroDiffs(t *testing.T) {
+	pairs := [][2]float64{{1, 1}, {2, 2}, {3, 3}}
+	W, p := Wilcoxon(pairs)
+	if W != 0 || p != 1 {
+		t.Errorf("Wilcoxon() = (%v, %v), want (0, 1) when every pair is tied", W, p)
+	}
+}
+
+func TestIQROutliers(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 100}
+	kept, dropped := IQROutliers(xs)
+	if len(dropped) != 1 || dropped[0] != 100 {
+		t.Errorf("IQROutliers() dropped = %v, want [100]", dropped)
+	}
+	if len(kept) != 9 {
+		t.Errorf("IQROutliers() kept has length %d, want 9", len(kept))
+	}
+}
+
+func TestIQROutliersEmptySamplePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("IQROutliers with an empty sample did not panic")
+		}
+	}()
+	IQROutliers(nil)
+}
+
+func TestGeoMean(t *testing.T) {
+	xs := []float64{1, 2, 4, 8}
+	if gm := GeoMean(xs); math.Abs(gm-2.8284271247461903) > 1e-9 {
+		t.Errorf("GeoMean() = %v, want %v", gm, 2.8284271247461903)
+	}
+}
+
+func TestGeoMeanEmptySamplePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("GeoMean with an empty sample did not panic")
+		}
+	}()
+	GeoMean(nil)
+}
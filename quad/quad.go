@@ -0,0 +1,176 @@
+// Package quad implements fixed-order Gauss-Legendre quadrature, for
+// computing definite integrals and, on top of that, expectations of
+// distributions.
+package quad
+
+import (
+	"math"
+	"sync"
+)
+
+// Distribution is the subset of a dist.Distribution's methods needed to
+// compute an expectation over it.
+type Distribution interface {
+	// Prob returns the value of the probability density function at x.
+	Prob(x float64) float64
+	// Quantile returns the inverse of the cumulative probability
+	// distribution at p.
+	Quantile(p float64) float64
+}
+
+// nodeCache holds the Gauss-Legendre nodes and weights on [-1,1] for a
+// given order n, computed once by Fixed and reused on later calls.
+// nodeCacheMu guards concurrent access from callers of Fixed or Expect.
+var (
+	nodeCacheMu sync.Mutex
+	nodeCache   = map[int][2][]float64{}
+)
+
+// Fixed returns the fixed-order Gauss-Legendre approximation of the
+// integral of f over [min, max], using n quadrature points. The nodes
+// and weights for a given n are computed once, via the Golub-Welsch
+// algorithm, and cached for reuse by later calls with the same n.
+func Fixed(f func(float64) float64, min, max float64, n int) float64 {
+	if n < 1 {
+		panic("quad: n too small")
+	}
+	if max < min {
+		panic("quad: max < min")
+	}
+	nodes, weights := legendreNodes(n)
+
+	half := (max - min) / 2
+	mid := (max + min) / 2
+	var sum float64
+	for i, xi := range nodes {
+		sum += weights[i] * f(half*xi+mid)
+	}
+	return sum * half
+}
+
+// Expect returns the expectation of g(x) under the density d.Prob,
+// computed by Gauss-Legendre quadrature with n points over an interval
+// derived from d's extreme quantiles. It can be used for moments,
+// entropy, or KL divergence of any Distribution, including ones whose
+// analytic forms are impractical or worth cross-validating numerically.
+func Expect(d Distribution, g func(float64) float64, n int) float64 {
+	min := d.Quantile(1e-9)
+	max := d.Quantile(1 - 1e-9)
+	return Fixed(func(x float64) float64 {
+		return g(x) * d.Prob(x)
+	}, min, max, n)
+}
+
+// legendreNodes returns the n-point Gauss-Legendre nodes and weights on
+// [-1,1], computed via the Golub-Welsch algorithm: the nodes are the
+// eigenvalues of the symmetric tridiagonal Jacobi matrix with zero
+// diagonal and off-diagonal entries βₖ = k/√(4k²-1), and the weights are
+// 2 times the square of the first component of each normalized
+// eigenvector.
+func legendreNodes(n int) (nodes, weights []float64) {
+	nodeCacheMu.Lock()
+	defer nodeCacheMu.Unlock()
+	if cached, ok := nodeCache[n]; ok {
+		return cached[0], cached[1]
+	}
+
+	jacobi := make([][]float64, n)
+	for i := range jacobi {
+		jacobi[i] = make([]float64, n)
+	}
+	for k := 1; k < n; k++ {
+		b := float64(k) / math.Sqrt(float64(4*k*k-1))
+		jacobi[k-1][k] = b
+		jacobi[k][k-1] = b
+	}
+
+	eigenvalues, eigenvectors := jacobiEigen(jacobi)
+	nodes = eigenvalues
+	weights = make([]float64, n)
+	for i := range weights {
+		weights[i] = 2 * eigenvectors[0][i] * eigenvectors[0][i]
+	}
+
+	// Sort by node position, carrying weights along.
+	for i := 1; i < n; i++ {
+		for j := i; j > 0 && nodes[j] < nodes[j-1]; j-- {
+			nodes[j], nodes[j-1] = nodes[j-1], nodes[j]
+			weights[j], weights[j-1] = weights[j-1], weights[j]
+		}
+	}
+
+	nodeCache[n] = [2][]float64{nodes, weights}
+	return nodes, weights
+}
+
+// jacobiEigen returns the eigenvalues and eigenvectors of the symmetric
+// matrix a, via the classical cyclic Jacobi eigenvalue algorithm. a is
+// overwritten. eigenvectors[i][j] is the ith component of the jth
+// eigenvector.
+func jacobiEigen(a [][]float64) (eigenvalues []float64, eigenvectors [][]float64) {
+	n := len(a)
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+
+	const (
+		maxSweeps = 100
+		tol       = 1e-28
+	)
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		var off float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j {
+					off += a[i][j] * a[i][j]
+				}
+			}
+		}
+		if off < tol {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				var t float64
+				if theta == 0 {
+					t = 1
+				} else {
+					t = math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				app, aqq, apq := a[p][p], a[q][q], a[p][q]
+				a[p][p] = c*c*app - 2*s*c*apq + s*s*aqq
+				a[q][q] = s*s*app + 2*s*c*apq + c*c*aqq
+				a[p][q], a[q][p] = 0, 0
+				for i := 0; i < n; i++ {
+					if i != p && i != q {
+						aip, aiq := a[i][p], a[i][q]
+						a[i][p] = c*aip - s*aiq
+						a[p][i] = a[i][p]
+						a[i][q] = s*aip + c*aiq
+						a[q][i] = a[i][q]
+					}
+				}
+				for i := 0; i < n; i++ {
+					vip, viq := v[i][p], v[i][q]
+					v[i][p] = c*vip - s*viq
+					v[i][q] = s*vip + c*viq
+				}
+			}
+		}
+	}
+
+	eigenvalues = make([]float64, n)
+	for i := range eigenvalues {
+		eigenvalues[i] = a[i][i]
+	}
+	return eigenvalues, v
+}
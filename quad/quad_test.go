@@ -0,0 +1,76 @@
+package quad
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Armadilloa16/stat/dist"
+)
+
+// TestFixedPolynomial checks Fixed against the exact integral of a
+// polynomial, ∫x³dx from 0 to 2 = 4, which an n-point Gauss-Legendre
+// rule integrates exactly for any n >= 2 since it has degree 3 < 2n.
+func TestFixedPolynomial(t *testing.T) {
+	f := func(x float64) float64 { return x * x * x }
+	for n := 2; n <= 5; n++ {
+		got := Fixed(f, 0, 2, n)
+		if want := 4.0; math.Abs(got-want) > 1e-9 {
+			t.Errorf("Fixed(x^3, 0, 2, %d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestFixedConstant(t *testing.T) {
+	f := func(x float64) float64 { return 3 }
+	if got := Fixed(f, -1, 1, 4); math.Abs(got-6) > 1e-9 {
+		t.Errorf("Fixed(3, -1, 1, 4) = %v, want 6", got)
+	}
+}
+
+func TestFixedPanicsOnBadInput(t *testing.T) {
+	f := func(x float64) float64 { return x }
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Fixed with n < 1 did not panic")
+			}
+		}()
+		Fixed(f, 0, 1, 0)
+	}()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Fixed with max < min did not panic")
+			}
+		}()
+		Fixed(f, 1, 0, 2)
+	}()
+}
+
+// TestExpectMean checks Expect against a Weibull distribution's own
+// closed-form Mean.
+func TestExpectMean(t *testing.T) {
+	d := dist.Weibull{K: 2, Lambda: 3}
+	got := Expect(d, func(x float64) float64 { return x }, 40)
+	want := d.Mean()
+	if math.Abs(got-want) > 1e-6 {
+		t.Errorf("Expect(identity) = %v, want Mean() = %v", got, want)
+	}
+}
+
+func TestLegendreNodesSymmetric(t *testing.T) {
+	nodes, weights := legendreNodes(5)
+	if len(nodes) != 5 || len(weights) != 5 {
+		t.Fatalf("legendreNodes(5) returned %d nodes, %d weights, want 5 and 5", len(nodes), len(weights))
+	}
+	var sum float64
+	for i, w := range weights {
+		sum += w
+		if math.Abs(nodes[i]+nodes[len(nodes)-1-i]) > 1e-12 {
+			t.Errorf("legendreNodes(5) nodes not symmetric about 0: %v", nodes)
+		}
+	}
+	if math.Abs(sum-2) > 1e-9 {
+		t.Errorf("legendreNodes(5) weights sum to %v, want 2", sum)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAUC(t *testing.T) {
+	fpr := []float64{0, 0, 1}
+	tpr := []float64{0, 1, 1}
+	if auc := AUC(tpr, fpr); math.Abs(auc-1) > 1e-9 {
+		t.Errorf("AUC() = %v, want 1 for a perfect ROC curve", auc)
+	}
+
+	fpr = []float64{0, 1}
+	tpr = []float64{0, 1}
+	if auc := AUC(tpr, fpr); math.Abs(auc-0.5) > 1e-9 {
+		t.Errorf("AUC() = %v, want 0.5 for a diagonal ROC curve", auc)
+	}
+}
+
+// TestAUCVarianceSeparated checks that a perfectly separated classifier,
+// where every positive score is lower than every negative score
+// (favouring the positive class under ROC's cutoff convention), gets an
+// AUC of 1.
+func TestAUCVarianceSeparated(t *testing.T) {
+	scores := []float64{1, 2, 3, 4, 5, 6}
+	classes := []bool{true, true, true, false, false, false}
+	auc, variance := AUCVariance(scores, classes)
+	if math.Abs(auc-1) > 1e-9 {
+		t.Errorf("AUCVariance() auc = %v, want 1 for perfectly separated classes", auc)
+	}
+	if variance < 0 {
+		t.Errorf("AUCVariance() variance = %v, want non-negative", variance)
+	}
+}
+
+// TestAUCVarianceAgreesWithROC checks that AUCVariance's DeLong AUC
+// matches the trapezoid AUC of ROC's own curve for the same data, i.e.
+// that the two share the same "lower score favours positive" polarity.
+func TestAUCVarianceAgreesWithROC(t *testing.T) {
+	y := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}
+	classes := []bool{false, true, false, true, false, true, true, true}
+	tpr, fpr := ROC(nil, y, classes, nil)
+	trapAUC := AUC(tpr, fpr)
+	delongAUC, _ := AUCVariance(y, classes)
+	if math.Abs(trapAUC-delongAUC) > 1e-9 {
+		t.Errorf("AUC(ROC(...)) = %v, AUCVariance(...) = %v, want equal", trapAUC, delongAUC)
+	}
+}
+
+func TestCompareROCIdentical(t *testing.T) {
+	scores := []float64{1, 2, 3, 4, 1.5, 2.5, 3.5, 4.5}
+	classes := []bool{true, true, true, true, false, false, false, false}
+	z, p := CompareROC(scores, scores, classes)
+	if z != 0 {
+		t.Errorf("CompareROC() z = %v, want 0 for identical curves", z)
+	}
+	if math.Abs(p-1) > 1e-9 {
+		t.Errorf("CompareROC() p = %v, want 1 for identical curves", p)
+	}
+}
+
+// TestCompareROCBetterClassifier checks that a classifier with better
+// separation gets a higher DeLong AUC and that CompareROC's Z statistic
+// is positive, favouring the first classifier.
+func TestCompareROCBetterClassifier(t *testing.T) {
+	classes := []bool{true, true, true, true, false, false, false, false}
+	better := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	worse := []float64{1, 2, 5, 6, 3, 4, 7, 8}
+
+	aucBetter, _ := AUCVariance(better, classes)
+	aucWorse, _ := AUCVariance(worse, classes)
+	if aucBetter <= aucWorse {
+		t.Errorf("AUCVariance: better classifier auc = %v, worse classifier auc = %v, want better > worse", aucBetter, aucWorse)
+	}
+
+	z, _ := CompareROC(better, worse, classes)
+	if z <= 0 {
+		t.Errorf("CompareROC() z = %v, want positive since the first classifier separates the classes better", z)
+	}
+}
+
+// TestPRPerfectSeparation checks that a perfectly separated classifier
+// (same convention as ROC: lower y favours the positive class) reaches
+// precision 1 and recall 1 somewhere on the curve, and that recall
+// matches the TPR reported by ROC on the same data.
+func TestPRPerfectSeparation(t *testing.T) {
+	y := []float64{1, 2, 3, 4, 5, 6}
+	classes := []bool{true, true, true, false, false, false}
+
+	precision, recall := PR(nil, y, classes, nil)
+	tpr, _ := ROC(nil, y, classes, nil)
+	if len(recall) != len(tpr) {
+		t.Fatalf("PR() recall has length %d, ROC() tpr has length %d, want equal", len(recall), len(tpr))
+	}
+	for i := range recall {
+		if math.Abs(recall[i]-tpr[i]) > 1e-9 {
+			t.Errorf("PR() recall[%d] = %v, ROC() tpr[%d] = %v, want equal", i, recall[i], i, tpr[i])
+		}
+	}
+
+	var maxPrecision, maxRecall float64
+	for i := range precision {
+		if precision[i] > maxPrecision {
+			maxPrecision = precision[i]
+		}
+		if recall[i] > maxRecall {
+			maxRecall = recall[i]
+		}
+	}
+	if math.Abs(maxPrecision-1) > 1e-9 {
+		t.Errorf("PR() max precision = %v, want 1 for a perfectly separated classifier", maxPrecision)
+	}
+	if math.Abs(maxRecall-1) > 1e-9 {
+		t.Errorf("PR() max recall = %v, want 1", maxRecall)
+	}
+}
+
+func TestAveragePrecision(t *testing.T) {
+	// A curve that steps straight from (recall=0, precision=1) to
+	// (recall=1, precision=1) has an average precision of 1.
+	precision := []float64{1, 1}
+	recall := []float64{0, 1}
+	if ap := AveragePrecision(precision, recall); math.Abs(ap-1) > 1e-9 {
+		t.Errorf("AveragePrecision() = %v, want 1", ap)
+	}
+}
+
+func TestF1AtCutoff(t *testing.T) {
+	precision := []float64{1, 0.5, 0}
+	recall := []float64{0, 0.5, 1}
+	f1 := F1AtCutoff(precision, recall, 1)
+	want := []float64{0, 0.5, 0}
+	for i := range want {
+		if math.Abs(f1[i]-want[i]) > 1e-9 {
+			t.Errorf("F1AtCutoff()[%d] = %v, want %v", i, f1[i], want[i])
+		}
+	}
+}